@@ -0,0 +1,23 @@
+// Package v1 contains the v1 API types of the onepassword.com group.
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupVersion is the group/version used to register these types.
+var GroupVersion = schema.GroupVersion{Group: "onepassword.com", Version: "v1"}
+
+// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+var SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+
+// AddToScheme adds the types in this group-version to the given scheme.
+var AddToScheme = SchemeBuilder.AddToScheme
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(GroupVersion, &OnePasswordItem{}, &OnePasswordItemList{})
+	metav1.AddToGroupVersion(scheme, GroupVersion)
+	return nil
+}
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OnePasswordItemSpec describes the 1Password item a Secret should be
+// created from.
+type OnePasswordItemSpec struct {
+	// ItemPath is the "vaults/<vaultId>/items/<itemId>" path of the item
+	// to sync into a Secret of the same name as this resource.
+	ItemPath string `json:"itemPath"`
+}
+
+// ServiceBinding reports the name of the Secret an OnePasswordItem was
+// projected into under the Kubernetes Service Binding specification, so
+// workloads written against that spec can discover it the way they
+// would any other ProvisionedService.
+type ServiceBinding struct {
+	// Name is the name of the Secret the binding was materialized into.
+	Name string `json:"name"`
+}
+
+// OnePasswordItemStatus is the observed state of an OnePasswordItem.
+type OnePasswordItemStatus struct {
+	// Conditions represent the latest available observations of this
+	// item's synchronization state: "Ready" and "Synced" are True once
+	// its Secret reflects the 1Password item, "Stale" is True whenever
+	// the last fetch from 1Password failed.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Binding is set once this item has been projected as a Service
+	// Binding Spec Secret.
+	// +optional
+	Binding *ServiceBinding `json:"binding,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// OnePasswordItem is the Schema for the onepassworditems API.
+type OnePasswordItem struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OnePasswordItemSpec   `json:"spec,omitempty"`
+	Status OnePasswordItemStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OnePasswordItemList contains a list of OnePasswordItem.
+type OnePasswordItemList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OnePasswordItem `json:"items"`
+}
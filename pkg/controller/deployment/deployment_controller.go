@@ -0,0 +1,76 @@
+// Package deployment watches Deployments for changes to the 1Password
+// Secrets they consume and restarts them in real time, complementing the
+// periodic sweep in onepassword.SecretUpdateHandler.UpdateKubernetesSecretsTask.
+package deployment
+
+import (
+	"context"
+	"flag"
+
+	"github.com/1Password/onepassword-operator/pkg/onepassword"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ManagedLabelSelector is the operator flag selecting which Deployments
+// are watched for real-time 1Password secret reconciliation. Deployments
+// that don't match it are left to the periodic
+// UpdateKubernetesSecretsTask sweep, so clusters with many Deployments
+// don't pay to hold all of their pod specs in cache.
+var ManagedLabelSelector = flag.String(
+	"deployment-label-selector",
+	"operator.1password.io/managed=true",
+	"label selector Deployments must match to be watched for 1Password secret updates",
+)
+
+// Reconciler restarts Deployments whose referenced Secrets have fallen
+// out of date with their 1Password item. It watches Deployments through
+// a metadata-only projection, so the controller-runtime cache never
+// holds a full pod spec per Deployment cluster-wide; a matching
+// Deployment's full spec is fetched from the API on demand in Reconcile.
+type Reconciler struct {
+	client.Client
+	Handler *onepassword.SecretUpdateHandler
+}
+
+// SetupWithManager registers the Reconciler with mgr, scoping its watch
+// to Deployments matching the selector parsed from ManagedLabelSelector.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	pred, err := newManagedPredicate(*ManagedLabelSelector)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.Deployment{}, builder.OnlyMetadata, builder.WithPredicates(pred)).
+		Complete(r)
+}
+
+// Reconcile fetches the full Deployment named by req and, if any Secret
+// it references has a newer 1Password item available, updates that
+// Secret and restarts the Deployment.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, req.NamespacedName, deployment); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return ctrl.Result{}, r.Handler.ReconcileDeployment(ctx, deployment)
+}
+
+// newManagedPredicate builds the predicate that keeps unlabeled
+// Deployments out of the reconcile queue entirely.
+func newManagedPredicate(selectorExpr string) (predicate.Predicate, error) {
+	selector, err := labels.Parse(selectorExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return selector.Matches(labels.Set(obj.GetLabels()))
+	}), nil
+}
@@ -0,0 +1,36 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestManagedPredicateExcludesUnlabeledDeployments(t *testing.T) {
+	pred, err := newManagedPredicate("operator.1password.io/managed=true")
+	assert.NoError(t, err)
+
+	unlabeled := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "unlabeled", Namespace: "default"},
+	}
+	assert.False(t, pred.Create(event.CreateEvent{Object: unlabeled}))
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: unlabeled, ObjectNew: unlabeled}))
+
+	labeled := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "labeled",
+			Namespace: "default",
+			Labels:    map[string]string{"operator.1password.io/managed": "true"},
+		},
+	}
+	assert.True(t, pred.Create(event.CreateEvent{Object: labeled}))
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: labeled, ObjectNew: labeled}))
+}
+
+func TestNewManagedPredicateRejectsInvalidSelector(t *testing.T) {
+	_, err := newManagedPredicate("===not a selector===")
+	assert.Error(t, err)
+}
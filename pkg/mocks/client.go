@@ -0,0 +1,23 @@
+package mocks
+
+import "github.com/1Password/connect-sdk-go/onepassword"
+
+// GetGetItemFunc and GetResolveReferenceFunc let each test stub out
+// TestClient's behavior without needing a bespoke mock type per case.
+var (
+	GetGetItemFunc          func(vaultRef, itemRef string) (*onepassword.Item, error)
+	GetResolveReferenceFunc func(secretRef string) (*onepassword.Item, error)
+)
+
+// TestClient is a onepassword.SecretProvider test double driven by the
+// package-level GetGetItemFunc/GetResolveReferenceFunc hooks, so the same
+// mock works against Connect, CLI, or Service Account backed handlers.
+type TestClient struct{}
+
+func (t *TestClient) GetItem(vaultRef, itemRef string) (*onepassword.Item, error) {
+	return GetGetItemFunc(vaultRef, itemRef)
+}
+
+func (t *TestClient) ResolveReference(secretRef string) (*onepassword.Item, error) {
+	return GetResolveReferenceFunc(secretRef)
+}
@@ -0,0 +1,54 @@
+package onepassword
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// cliProvider is the SecretProvider backed by a locally installed `op`
+// CLI, authenticated however the operator's environment already is
+// (OP_SESSION_*, a mounted config directory, or extraEnv below).
+type cliProvider struct {
+	extraEnv []string
+}
+
+func newCLIProvider() *cliProvider {
+	return &cliProvider{}
+}
+
+func (p *cliProvider) GetItem(vaultRef, itemRef string) (*Item, error) {
+	out, err := p.run("item", "get", itemRef, "--vault", vaultRef, "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	item := &Item{}
+	if err := json.Unmarshal(out, item); err != nil {
+		return nil, fmt.Errorf("parsing `op item get` output: %w", err)
+	}
+	return item, nil
+}
+
+func (p *cliProvider) ResolveReference(secretRef string) (*Item, error) {
+	vaultRef, itemRef, _, err := parseSecretReference(secretRef)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetItem(vaultRef, itemRef)
+}
+
+func (p *cliProvider) run(args ...string) ([]byte, error) {
+	cmd := exec.Command("op", args...)
+	cmd.Env = append(cmd.Environ(), p.extraEnv...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running op %v: %w: %s", args, err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,65 @@
+package onepassword
+
+import (
+	onepasswordv1 "github.com/1Password/onepassword-operator/pkg/apis/onepassword/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionReady is True once item's Secret has been confirmed to
+	// reflect its 1Password item, and False whenever the last fetch from
+	// 1Password failed.
+	ConditionReady = "Ready"
+
+	// ConditionSynced is True as of the last fetch that successfully
+	// brought item's Secret up to date with its 1Password item.
+	ConditionSynced = "Synced"
+
+	// ConditionStale is the inverse of ConditionSynced: True whenever the
+	// last fetch from 1Password failed, so item's Secret may be serving
+	// an out-of-date value.
+	ConditionStale = "Stale"
+)
+
+// setSyncedCondition marks item Ready and Synced, not Stale: the state
+// once its Secret has been confirmed to reflect its 1Password item.
+func setSyncedCondition(item *onepasswordv1.OnePasswordItem, reason, message string) {
+	setCondition(item, ConditionReady, metav1.ConditionTrue, reason, message)
+	setCondition(item, ConditionSynced, metav1.ConditionTrue, reason, message)
+	setCondition(item, ConditionStale, metav1.ConditionFalse, reason, message)
+}
+
+// setStaleCondition marks item Stale and not Ready: the state once a
+// fetch from 1Password has failed for reason/message.
+func setStaleCondition(item *onepasswordv1.OnePasswordItem, reason, message string) {
+	setCondition(item, ConditionReady, metav1.ConditionFalse, reason, message)
+	setCondition(item, ConditionSynced, metav1.ConditionFalse, reason, message)
+	setCondition(item, ConditionStale, metav1.ConditionTrue, reason, message)
+}
+
+// setCondition upserts the condition of conditionType on item's status,
+// following the standard Kubernetes convention of only bumping
+// LastTransitionTime when Status itself changes.
+func setCondition(item *onepasswordv1.OnePasswordItem, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	for i := range item.Status.Conditions {
+		c := &item.Status.Conditions[i]
+		if c.Type != conditionType {
+			continue
+		}
+		if c.Status != status {
+			c.Status = status
+			c.LastTransitionTime = metav1.Now()
+		}
+		c.Reason = reason
+		c.Message = message
+		return
+	}
+
+	item.Status.Conditions = append(item.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
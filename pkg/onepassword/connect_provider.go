@@ -0,0 +1,28 @@
+package onepassword
+
+import (
+	connect "github.com/1Password/connect-sdk-go/connect"
+)
+
+// connectProvider is the SecretProvider backed by a 1Password Connect
+// server. It is the default backend, and the only one earlier releases
+// of the operator supported.
+type connectProvider struct {
+	client connect.Client
+}
+
+func newConnectProvider(host, token string) *connectProvider {
+	return &connectProvider{client: connect.NewClient(host, token)}
+}
+
+func (p *connectProvider) GetItem(vaultRef, itemRef string) (*Item, error) {
+	return p.client.GetItem(itemRef, vaultRef)
+}
+
+func (p *connectProvider) ResolveReference(secretRef string) (*Item, error) {
+	vaultRef, itemRef, _, err := parseSecretReference(secretRef)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetItem(vaultRef, itemRef)
+}
@@ -0,0 +1,71 @@
+package onepassword
+
+import (
+	"net/http"
+	"strings"
+
+	connectop "github.com/1Password/connect-sdk-go/onepassword"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Event reasons SecretUpdateHandler emits against the Secret or
+// Deployment a reconcile touched, surfaced via "kubectl describe" and
+// "kubectl get events". SecretUpdateHandler only updates Secrets that
+// already exist, so it never emits EventReasonSecretCreated.
+const (
+	EventReasonItemFetched         = "ItemFetched"
+	EventReasonSecretCreated       = "SecretCreated"
+	EventReasonSecretUpdated       = "SecretUpdated"
+	EventReasonDeploymentRestarted = "DeploymentRestarted"
+	EventReasonFetchFailed         = "FetchFailed"
+	EventReasonVaultNotFound       = "VaultNotFound"
+	EventReasonItemNotFound        = "ItemNotFound"
+	EventReasonPermissionDenied    = "PermissionDenied"
+
+	eventReportingController = "onepassword-operator"
+	eventReportingAction     = "SyncSecret"
+)
+
+// event records a single event against regarding, if h was built with a
+// recorder. It is always a no-op-safe call: a nil recorder (e.g. in
+// tests that don't care about events) just drops it.
+func (h *SecretUpdateHandler) event(regarding runtime.Object, eventType, reason, message string) {
+	if h.recorder == nil {
+		return
+	}
+	h.recorder.Eventf(regarding, nil, eventType, reason, eventReportingAction, message)
+}
+
+// classifyFetchError maps a SecretProvider error to the event reason it
+// warrants. Errors from the 1Password Connect backend carry a status
+// code that distinguishes a missing vault or item from a permissions
+// problem; any other error (a CLI failure, a network error) is reported
+// as the generic FetchFailed.
+func classifyFetchError(err error) (reason, message string) {
+	opErr, ok := err.(*connectop.Error)
+	if !ok {
+		return EventReasonFetchFailed, err.Error()
+	}
+
+	switch opErr.StatusCode {
+	case http.StatusForbidden:
+		return EventReasonPermissionDenied, opErr.Message
+	case http.StatusNotFound:
+		if strings.Contains(strings.ToLower(opErr.Message), "vault") {
+			return EventReasonVaultNotFound, opErr.Message
+		}
+		return EventReasonItemNotFound, opErr.Message
+	default:
+		return EventReasonFetchFailed, opErr.Message
+	}
+}
+
+// recordFetchFailure emits the event classifyFetchError(err) warrants
+// against secret, and marks secret's owning OnePasswordItem (if any)
+// Stale for the same reason.
+func (h *SecretUpdateHandler) recordFetchFailure(secret *corev1.Secret, err error) {
+	reason, message := classifyFetchError(err)
+	h.event(secret, corev1.EventTypeWarning, reason, message)
+	h.markItemStale(secret, reason, message)
+}
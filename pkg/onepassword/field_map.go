@@ -0,0 +1,116 @@
+package onepassword
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// FieldMapAnnotation expands a 1Password field whose value is a
+	// comma- or newline-separated list into several Secret keys or a
+	// single JSON-encoded key, instead of the raw delimited string
+	// kubernetesSecretDataFromItem would otherwise write. Its value is a
+	// ";"-separated list of "<fieldRef>=<mode>" rules, where fieldRef is
+	// a field label or a "<section label>.<field label>" reference (the
+	// same syntax onepassword.Item.GetValue understands) and mode is
+	// "indexed" or "json".
+	FieldMapAnnotation = "operator.1password.io/field-map"
+
+	fieldMapModeIndexed = "indexed"
+	fieldMapModeJSON    = "json"
+)
+
+// fieldMapping is one "<fieldRef>=<mode>" rule parsed from
+// FieldMapAnnotation.
+type fieldMapping struct {
+	fieldRef string
+	key      string
+	mode     string
+}
+
+// parseFieldMap parses FieldMapAnnotation's ";"-separated
+// "<fieldRef>=<mode>" rules.
+func parseFieldMap(raw string) ([]fieldMapping, error) {
+	var mappings []fieldMapping
+	for _, rule := range strings.Split(raw, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		fieldRef, mode, ok := strings.Cut(rule, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid field-map rule %q: expected <fieldRef>=<mode>", rule)
+		}
+
+		fieldRef = strings.TrimSpace(fieldRef)
+		mode = strings.TrimSpace(mode)
+		if fieldRef == "" {
+			return nil, fmt.Errorf("invalid field-map rule %q: missing field reference", rule)
+		}
+		if mode != fieldMapModeIndexed && mode != fieldMapModeJSON {
+			return nil, fmt.Errorf("invalid field-map rule %q: unknown mode %q, want %q or %q", rule, mode, fieldMapModeIndexed, fieldMapModeJSON)
+		}
+
+		mappings = append(mappings, fieldMapping{fieldRef: fieldRef, key: fieldMapKey(fieldRef), mode: mode})
+	}
+	return mappings, nil
+}
+
+// fieldMapKey derives the Secret data key a fieldRef expands into: the
+// field label, dropping any "<section>." prefix.
+func fieldMapKey(fieldRef string) string {
+	if _, field, ok := strings.Cut(fieldRef, "."); ok {
+		return field
+	}
+	return fieldRef
+}
+
+// splitListValue normalizes a comma- or newline-separated field value
+// into its list entries, trimming whitespace and dropping empty entries
+// so insignificant formatting differences (blank lines, trailing
+// commas) don't change the resulting keys.
+func splitListValue(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+
+	entries := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if trimmed := strings.TrimSpace(f); trimmed != "" {
+			entries = append(entries, trimmed)
+		}
+	}
+	return entries
+}
+
+// applyFieldMappings expands every rule in mappings against item,
+// writing the result into data and removing the single raw key
+// kubernetesSecretDataFromItem wrote for that field. It errors if a
+// rule's field reference does not resolve to a value on item.
+func applyFieldMappings(item *Item, mappings []fieldMapping, data map[string][]byte) error {
+	for _, m := range mappings {
+		value := item.GetValue(m.fieldRef)
+		if value == "" {
+			return fmt.Errorf("field-map rule %q: no field %q found on item %q", m.fieldRef+"="+m.mode, m.fieldRef, item.Title)
+		}
+
+		delete(data, m.key)
+		entries := splitListValue(value)
+
+		switch m.mode {
+		case fieldMapModeJSON:
+			encoded, err := json.Marshal(entries)
+			if err != nil {
+				return fmt.Errorf("field-map rule for %q: %w", m.fieldRef, err)
+			}
+			data[m.key] = encoded
+		case fieldMapModeIndexed:
+			for i, entry := range entries {
+				data[fmt.Sprintf("%s.%d", m.key, i)] = []byte(entry)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package onepassword
+
+import (
+	"testing"
+
+	"github.com/1Password/connect-sdk-go/onepassword"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFieldMap(t *testing.T) {
+	tests := []struct {
+		testName string
+		raw      string
+		want     []fieldMapping
+		wantErr  bool
+	}{
+		{
+			testName: "single indexed rule",
+			raw:      "hosts=indexed",
+			want:     []fieldMapping{{fieldRef: "hosts", key: "hosts", mode: fieldMapModeIndexed}},
+		},
+		{
+			testName: "nested section reference",
+			raw:      "database.hosts=json",
+			want:     []fieldMapping{{fieldRef: "database.hosts", key: "hosts", mode: fieldMapModeJSON}},
+		},
+		{
+			testName: "multiple rules separated by semicolons",
+			raw:      "hosts=indexed;database.replicas=json",
+			want: []fieldMapping{
+				{fieldRef: "hosts", key: "hosts", mode: fieldMapModeIndexed},
+				{fieldRef: "database.replicas", key: "replicas", mode: fieldMapModeJSON},
+			},
+		},
+		{
+			testName: "missing mode is malformed",
+			raw:      "hosts",
+			wantErr:  true,
+		},
+		{
+			testName: "unknown mode is malformed",
+			raw:      "hosts=explode",
+			wantErr:  true,
+		},
+		{
+			testName: "empty field reference is malformed",
+			raw:      "=indexed",
+			wantErr:  true,
+		},
+	}
+
+	for _, testData := range tests {
+		t.Run(testData.testName, func(t *testing.T) {
+			got, err := parseFieldMap(testData.raw)
+			if testData.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testData.want, got)
+		})
+	}
+}
+
+func TestSplitListValue(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, splitListValue("a,b,c"))
+	assert.Equal(t, []string{"a", "b", "c"}, splitListValue("a\nb\nc"))
+	assert.Equal(t, []string{"a", "b"}, splitListValue(" a , \n, b ,\n"))
+}
+
+func TestApplyFieldMappings(t *testing.T) {
+	section := &onepassword.ItemSection{ID: "sec1", Label: "database"}
+	item := &onepassword.Item{
+		Title:    "test-item",
+		Sections: []*onepassword.ItemSection{section},
+		Fields: []*onepassword.ItemField{
+			{Label: "hosts", Value: "a.example.com, b.example.com"},
+			{Label: "replicas", Section: section, Value: "r1\nr2\nr3"},
+		},
+	}
+
+	t.Run("indexed mode expands a list field into numbered keys", func(t *testing.T) {
+		data := map[string][]byte{"hosts": []byte("a.example.com, b.example.com")}
+		mappings := []fieldMapping{{fieldRef: "hosts", key: "hosts", mode: fieldMapModeIndexed}}
+
+		assert.NoError(t, applyFieldMappings(item, mappings, data))
+		assert.Equal(t, map[string][]byte{
+			"hosts.0": []byte("a.example.com"),
+			"hosts.1": []byte("b.example.com"),
+		}, data)
+	})
+
+	t.Run("json mode encodes a nested section field as a JSON array", func(t *testing.T) {
+		data := map[string][]byte{"replicas": []byte("r1\nr2\nr3")}
+		mappings := []fieldMapping{{fieldRef: "database.replicas", key: "replicas", mode: fieldMapModeJSON}}
+
+		assert.NoError(t, applyFieldMappings(item, mappings, data))
+		assert.Equal(t, map[string][]byte{"replicas": []byte(`["r1","r2","r3"]`)}, data)
+	})
+
+	t.Run("unresolvable field reference errors", func(t *testing.T) {
+		data := map[string][]byte{}
+		mappings := []fieldMapping{{fieldRef: "does-not-exist", key: "does-not-exist", mode: fieldMapModeIndexed}}
+
+		assert.Error(t, applyFieldMappings(item, mappings, data))
+	})
+}
@@ -0,0 +1,54 @@
+package onepassword
+
+import (
+	"fmt"
+
+	connectop "github.com/1Password/connect-sdk-go/onepassword"
+)
+
+// Item is the 1Password item representation shared by every
+// SecretProvider implementation. It is a type alias for connect-sdk-go's
+// Item so callers that already depend on its field/section helpers keep
+// working unchanged across backends.
+type Item = connectop.Item
+
+// SecretProvider is implemented by every supported way of reaching
+// 1Password: a Connect server, the op CLI, and 1Password Service
+// Accounts. SecretUpdateHandler is written entirely against this
+// interface so the annotation-driven reconcile logic in this package
+// does not need to know which backend is configured.
+type SecretProvider interface {
+	// GetItem fetches a single item from the vault it belongs to.
+	GetItem(vaultRef, itemRef string) (*Item, error)
+
+	// ResolveReference fetches the item addressed by a
+	// "op://vault/item[/field]" style secret reference.
+	ResolveReference(secretRef string) (*Item, error)
+}
+
+// ProviderType selects which SecretProvider backend NewSecretProvider
+// constructs.
+type ProviderType string
+
+const (
+	ProviderTypeConnect        ProviderType = "connect"
+	ProviderTypeCLI            ProviderType = "cli"
+	ProviderTypeServiceAccount ProviderType = "service-account"
+)
+
+// NewSecretProvider builds the SecretProvider selected by providerType.
+// host is only meaningful for ProviderTypeConnect. token is the Connect
+// token for ProviderTypeConnect and the Service Account token passed to
+// the op CLI for ProviderTypeServiceAccount; ProviderTypeCLI uses neither.
+func NewSecretProvider(providerType ProviderType, host, token string) (SecretProvider, error) {
+	switch providerType {
+	case ProviderTypeConnect, "":
+		return newConnectProvider(host, token), nil
+	case ProviderTypeCLI:
+		return newCLIProvider(), nil
+	case ProviderTypeServiceAccount:
+		return newServiceAccountProvider(token), nil
+	default:
+		return nil, fmt.Errorf("unknown 1Password secret provider type %q", providerType)
+	}
+}
@@ -0,0 +1,33 @@
+package onepassword
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseSecretReference splits a "op://vault/item" or
+// "op://vault/item/field" secret reference into its vault, item, and
+// optional field components.
+func parseSecretReference(secretRef string) (vaultRef, itemRef, field string, err error) {
+	trimmed := strings.TrimPrefix(secretRef, "op://")
+	parts := strings.Split(trimmed, "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("invalid secret reference %q: expected op://<vault>/<item>[/<field>]", secretRef)
+	}
+
+	vaultRef, itemRef = parts[0], parts[1]
+	if len(parts) > 2 {
+		field = parts[2]
+	}
+	return vaultRef, itemRef, field, nil
+}
+
+// parseItemPath splits a "vaults/<vaultId>/items/<itemId>" item path, the
+// form stored in ItemPathAnnotation, into its vault and item ids.
+func parseItemPath(itemPath string) (vaultID, itemID string, err error) {
+	parts := strings.Split(itemPath, "/")
+	if len(parts) != 4 || parts[0] != "vaults" || parts[2] != "items" {
+		return "", "", fmt.Errorf("invalid item path %q: expected vaults/<vaultId>/items/<itemId>", itemPath)
+	}
+	return parts[1], parts[3], nil
+}
@@ -0,0 +1,45 @@
+package onepassword
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateWithRetry applies mutate to obj and issues an Update, refreshing
+// obj in place with a Get, reapplying mutate and retrying whenever the API
+// server reports a conflict, bounded by retry.DefaultRetry. A NotFound
+// during Update is treated as "obj was deleted concurrently" rather than
+// as an error: it stops the retry and is reported via the returned
+// exists value. A BadRequest is wrapped with obj's identity and
+// annotationKey so a malformed annotation is easy to spot in logs.
+func (h *SecretUpdateHandler) updateWithRetry(ctx context.Context, obj client.Object, annotationKey string, mutate func()) (exists bool, err error) {
+	exists = true
+
+	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		mutate()
+
+		err := h.client.Update(ctx, obj)
+		switch {
+		case err == nil:
+			return nil
+		case apierrors.IsNotFound(err):
+			exists = false
+			return nil
+		case apierrors.IsConflict(err):
+			if getErr := h.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); getErr != nil {
+				return getErr
+			}
+			return err
+		case apierrors.IsBadRequest(err):
+			return fmt.Errorf("updating %s/%s annotation %s: %w", obj.GetNamespace(), obj.GetName(), annotationKey, err)
+		default:
+			return err
+		}
+	})
+
+	return exists, retryErr
+}
@@ -0,0 +1,366 @@
+package onepassword
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	onepasswordv1 "github.com/1Password/onepassword-operator/pkg/apis/onepassword/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// NameAnnotation, set on a Deployment, names the Secret it consumes
+	// so the Deployment is restarted on update even when nothing in its
+	// pod spec references that Secret directly.
+	NameAnnotation = "operator.1password.io/item-name"
+
+	// ItemPathAnnotation holds the "vaults/<id>/items/<id>" path of the
+	// 1Password item a Secret was created from.
+	ItemPathAnnotation = "operator.1password.io/item-path"
+
+	// VersionAnnotation records the 1Password item version a Secret was
+	// last synced from, so reconciles are no-ops until the item changes.
+	VersionAnnotation = "operator.1password.io/item-version"
+
+	// RestartAnnotation is stamped onto a Deployment's pod template to
+	// force a rollout after one of its Secrets has been updated.
+	RestartAnnotation = "operator.1password.io/last-restarted"
+)
+
+// SecretUpdateHandler reconciles Kubernetes Secrets against the
+// 1Password items they were created from, and restarts any Deployment
+// that consumes a Secret it updates. It is backend-agnostic: it talks to
+// 1Password exclusively through the SecretProvider interface.
+type SecretUpdateHandler struct {
+	client         client.Client
+	secretProvider SecretProvider
+	recorder       events.EventRecorder
+}
+
+// NewSecretUpdateHandler builds a SecretUpdateHandler for the given
+// Kubernetes client and 1Password backend. Events are recorded through
+// recorder; pass nil to run without event recording.
+func NewSecretUpdateHandler(k8sClient client.Client, secretProvider SecretProvider, recorder events.EventRecorder) *SecretUpdateHandler {
+	return &SecretUpdateHandler{client: k8sClient, secretProvider: secretProvider, recorder: recorder}
+}
+
+// UpdateKubernetesSecretsTask refreshes every Secret annotated with a
+// 1Password item path from that item, then restarts any Deployment that
+// consumes one of the Secrets it updated.
+func (h *SecretUpdateHandler) UpdateKubernetesSecretsTask() error {
+	secrets := &corev1.SecretList{}
+	if err := h.client.List(context.Background(), secrets); err != nil {
+		return err
+	}
+
+	updatedSecrets := map[string]bool{}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		itemPath, ok := secret.Annotations[ItemPathAnnotation]
+		if !ok {
+			continue
+		}
+
+		updated, err := h.updateSecretFromItemPath(secret, itemPath)
+		if err != nil {
+			return err
+		}
+		if updated {
+			updatedSecrets[secret.Name] = true
+		}
+	}
+
+	if len(updatedSecrets) == 0 {
+		return nil
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := h.client.List(context.Background(), deployments); err != nil {
+		return err
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		boundSecrets := referencedUpdatedSecretNames(deployment, updatedSecrets)
+		if len(boundSecrets) == 0 {
+			continue
+		}
+		if err := h.restartDeployment(deployment, boundSecrets); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateSecretFromItemPath fetches the 1Password item at itemPath and,
+// if its version differs from secret's VersionAnnotation, overwrites the
+// Secret's data and annotation with the refreshed item. It reports
+// whether the Secret was updated.
+func (h *SecretUpdateHandler) updateSecretFromItemPath(secret *corev1.Secret, itemPath string) (bool, error) {
+	vaultID, itemID, err := parseItemPath(itemPath)
+	if err != nil {
+		return false, err
+	}
+
+	item, err := h.secretProvider.GetItem(vaultID, itemID)
+	if err != nil {
+		h.recordFetchFailure(secret, err)
+		return false, err
+	}
+	h.event(secret, corev1.EventTypeNormal, EventReasonItemFetched, "fetched item from 1Password")
+
+	newVersion := fmt.Sprint(item.Version)
+	if secret.Annotations[VersionAnnotation] == newVersion {
+		if err := h.syncOwnerItemStatus(secret); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	data, err := buildSecretData(item, secret.Annotations)
+	if err != nil {
+		return false, err
+	}
+
+	mutate := func() {
+		secret.Data = data
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[VersionAnnotation] = newVersion
+	}
+
+	exists, err := h.updateWithRetry(context.Background(), secret, VersionAnnotation, mutate)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, nil
+	}
+	h.event(secret, corev1.EventTypeNormal, EventReasonSecretUpdated, "refreshed secret data from 1Password item")
+
+	if err := h.syncOwnerItemStatus(secret); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// syncOwnerItemStatus marks the OnePasswordItem secret was created from,
+// if OwnerItemAnnotation names one, Ready and Synced, and mirrors a
+// service-binding-projected secret's name onto status.binding.name.
+func (h *SecretUpdateHandler) syncOwnerItemStatus(secret *corev1.Secret) error {
+	item, ok, err := h.getOwnerItem(secret)
+	if !ok || err != nil {
+		return err
+	}
+
+	if isServiceBindingProjection(secret.Annotations) {
+		item.Status.Binding = &onepasswordv1.ServiceBinding{Name: secret.Name}
+	}
+	setSyncedCondition(item, "ItemSynced", "secret reflects the latest 1Password item version")
+
+	return h.client.Status().Update(context.Background(), item)
+}
+
+// markItemStale marks the OnePasswordItem secret was created from, if
+// OwnerItemAnnotation names one, Stale for reason/message. Failures to
+// load or update it are swallowed: they must never mask the fetch error
+// that triggered this in the first place.
+func (h *SecretUpdateHandler) markItemStale(secret *corev1.Secret, reason, message string) {
+	item, ok, err := h.getOwnerItem(secret)
+	if !ok || err != nil {
+		return
+	}
+
+	setStaleCondition(item, reason, message)
+	_ = h.client.Status().Update(context.Background(), item)
+}
+
+// getOwnerItem fetches the OnePasswordItem named by secret's
+// OwnerItemAnnotation. ok is false if secret has no such annotation, or
+// if the OnePasswordItem it names no longer exists.
+func (h *SecretUpdateHandler) getOwnerItem(secret *corev1.Secret) (item *onepasswordv1.OnePasswordItem, ok bool, err error) {
+	ownerName, hasOwner := secret.Annotations[OwnerItemAnnotation]
+	if !hasOwner {
+		return nil, false, nil
+	}
+
+	item = &onepasswordv1.OnePasswordItem{}
+	key := client.ObjectKey{Name: ownerName, Namespace: secret.Namespace}
+	if err := h.client.Get(context.Background(), key, item); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return item, true, nil
+}
+
+// restartDeployment stamps deployment's pod template with
+// RestartAnnotation to force a rollout, injecting a Service Binding Spec
+// projection for each of boundSecrets first if deployment has opted in.
+func (h *SecretUpdateHandler) restartDeployment(deployment *appsv1.Deployment, boundSecrets []string) error {
+	mutate := func() {
+		if isServiceBindingProjection(deployment.Annotations) {
+			for _, secretName := range boundSecrets {
+				injectServiceBindingProjection(deployment, secretName)
+			}
+		}
+
+		if deployment.Spec.Template.Annotations == nil {
+			deployment.Spec.Template.Annotations = map[string]string{}
+		}
+		deployment.Spec.Template.Annotations[RestartAnnotation] = time.Now().Format(time.RFC3339)
+	}
+
+	exists, err := h.updateWithRetry(context.Background(), deployment, RestartAnnotation, mutate)
+	if err != nil || !exists {
+		return err
+	}
+	h.event(deployment, corev1.EventTypeNormal, EventReasonDeploymentRestarted, "restarted to pick up updated secret data")
+	return nil
+}
+
+// buildSecretData maps item to Secret data the way annotations request:
+// kubernetesSecretDataForServiceBinding if a service binding projection
+// is requested, kubernetesSecretDataFromItem otherwise, then expands any
+// FieldMapAnnotation rules against the result.
+func buildSecretData(item *Item, annotations map[string]string) (map[string][]byte, error) {
+	var data map[string][]byte
+	if isServiceBindingProjection(annotations) {
+		data = kubernetesSecretDataForServiceBinding(item, annotations[BindingTypeAnnotation])
+	} else {
+		data = kubernetesSecretDataFromItem(item)
+	}
+
+	fieldMapRaw, ok := annotations[FieldMapAnnotation]
+	if !ok {
+		return data, nil
+	}
+
+	mappings, err := parseFieldMap(fieldMapRaw)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyFieldMappings(item, mappings, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// kubernetesSecretDataFromItem maps each labeled field on a 1Password
+// item to a Secret data key of the same name.
+func kubernetesSecretDataFromItem(item *Item) map[string][]byte {
+	data := map[string][]byte{}
+	for _, field := range item.Fields {
+		if field.Label == "" {
+			continue
+		}
+		data[field.Label] = []byte(field.Value)
+	}
+	return data
+}
+
+// referencedSecretNames returns the names, in first-seen order, of every
+// Secret deployment consumes, whether via NameAnnotation or via a
+// container env/envFrom/volume reference.
+func referencedSecretNames(deployment *appsv1.Deployment) []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(secretName string) {
+		if secretName == "" || seen[secretName] {
+			return
+		}
+		seen[secretName] = true
+		names = append(names, secretName)
+	}
+
+	add(deployment.Annotations[NameAnnotation])
+
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+				continue
+			}
+			add(env.ValueFrom.SecretKeyRef.Name)
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				add(envFrom.SecretRef.Name)
+			}
+		}
+	}
+
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		if volume.Secret != nil {
+			add(volume.Secret.SecretName)
+		}
+	}
+
+	return names
+}
+
+// referencedUpdatedSecretNames returns the subset of
+// referencedSecretNames(deployment) present in updatedSecrets. An empty
+// result means deployment does not need to be restarted.
+func referencedUpdatedSecretNames(deployment *appsv1.Deployment, updatedSecrets map[string]bool) []string {
+	var names []string
+	for _, secretName := range referencedSecretNames(deployment) {
+		if isUpdatedSecret(secretName, updatedSecrets) {
+			names = append(names, secretName)
+		}
+	}
+	return names
+}
+
+func isUpdatedSecret(secretName string, updatedSecrets map[string]bool) bool {
+	return updatedSecrets[secretName]
+}
+
+// ReconcileDeployment refreshes every Secret deployment references that
+// carries a 1Password item path, then restarts deployment if any of them
+// changed. Unlike UpdateKubernetesSecretsTask, which sweeps every Secret
+// in the cluster on a timer, this is driven by a single Deployment and is
+// the entry point for the event-based watch in pkg/controller/deployment.
+func (h *SecretUpdateHandler) ReconcileDeployment(ctx context.Context, deployment *appsv1.Deployment) error {
+	updatedSecrets := map[string]bool{}
+
+	for _, secretName := range referencedSecretNames(deployment) {
+		secret := &corev1.Secret{}
+		key := client.ObjectKey{Name: secretName, Namespace: deployment.Namespace}
+		if err := h.client.Get(ctx, key, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		itemPath, ok := secret.Annotations[ItemPathAnnotation]
+		if !ok {
+			continue
+		}
+
+		updated, err := h.updateSecretFromItemPath(secret, itemPath)
+		if err != nil {
+			return err
+		}
+		if updated {
+			updatedSecrets[secretName] = true
+		}
+	}
+
+	if len(updatedSecrets) == 0 {
+		return nil
+	}
+
+	return h.restartDeployment(deployment, referencedUpdatedSecretNames(deployment, updatedSecrets))
+}
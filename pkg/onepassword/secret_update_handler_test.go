@@ -3,19 +3,24 @@ package onepassword
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/1Password/onepassword-operator/pkg/mocks"
 
 	"github.com/1Password/connect-sdk-go/onepassword"
+	onepasswordv1 "github.com/1Password/onepassword-operator/pkg/apis/onepassword/v1"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	errors2 "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -31,17 +36,19 @@ const (
 	userKey              = "username"
 	passKey              = "password"
 	itemVersion          = 123
+	ownerItemName        = "test-item-owner"
 )
 
 type testUpdateSecretTask struct {
-	testName             string
-	existingDeployment   *appsv1.Deployment
-	existingSecret       *corev1.Secret
-	expectedError        error
-	expectedResultSecret *corev1.Secret
-	expectedEvents       []string
-	opItem               map[string]string
-	expectedRestart      bool
+	testName               string
+	existingDeployment     *appsv1.Deployment
+	existingSecret         *corev1.Secret
+	expectedError          error
+	expectedResultSecret   *corev1.Secret
+	expectedEvents         []string
+	opItem                 map[string]string
+	expectedRestart        bool
+	expectedServiceBinding bool
 }
 
 var (
@@ -49,6 +56,11 @@ var (
 		"password": []byte(password),
 		"username": []byte(username),
 	}
+	expectedServiceBindingSecretData = map[string][]byte{
+		"password": []byte(password),
+		"username": []byte(username),
+		"type":     []byte("postgresql"),
+	}
 	itemPath = fmt.Sprintf("vaults/%v/items/%v", vaultId, itemId)
 )
 
@@ -74,8 +86,9 @@ var tests = []testUpdateSecretTask{
 				Name:      name,
 				Namespace: namespace,
 				Annotations: map[string]string{
-					VersionAnnotation:  "old version",
-					ItemPathAnnotation: itemPath,
+					VersionAnnotation:   "old version",
+					ItemPathAnnotation:  itemPath,
+					OwnerItemAnnotation: ownerItemName,
 				},
 			},
 			Data: expectedSecretData,
@@ -97,6 +110,7 @@ var tests = []testUpdateSecretTask{
 			passKey: password,
 		},
 		expectedRestart: false,
+		expectedEvents:  []string{EventReasonItemFetched, EventReasonSecretUpdated},
 	},
 	{
 		testName: "OP item has new version. Secret needs update. Deployment is restarted based on containers",
@@ -138,8 +152,9 @@ var tests = []testUpdateSecretTask{
 				Name:      name,
 				Namespace: namespace,
 				Annotations: map[string]string{
-					VersionAnnotation:  "old version",
-					ItemPathAnnotation: itemPath,
+					VersionAnnotation:   "old version",
+					ItemPathAnnotation:  itemPath,
+					OwnerItemAnnotation: ownerItemName,
 				},
 			},
 			Data: expectedSecretData,
@@ -161,6 +176,7 @@ var tests = []testUpdateSecretTask{
 			passKey: password,
 		},
 		expectedRestart: true,
+		expectedEvents:  []string{EventReasonItemFetched, EventReasonSecretUpdated, EventReasonDeploymentRestarted},
 	},
 	{
 		testName: "OP item has new version. Secret needs update. Deployment is restarted based on annotation",
@@ -183,8 +199,9 @@ var tests = []testUpdateSecretTask{
 				Name:      name,
 				Namespace: namespace,
 				Annotations: map[string]string{
-					VersionAnnotation:  "old version",
-					ItemPathAnnotation: itemPath,
+					VersionAnnotation:   "old version",
+					ItemPathAnnotation:  itemPath,
+					OwnerItemAnnotation: ownerItemName,
 				},
 			},
 			Data: expectedSecretData,
@@ -206,6 +223,7 @@ var tests = []testUpdateSecretTask{
 			passKey: password,
 		},
 		expectedRestart: true,
+		expectedEvents:  []string{EventReasonItemFetched, EventReasonSecretUpdated, EventReasonDeploymentRestarted},
 	},
 	{
 		testName: "OP item has new version. Secret needs update. Deployment is restarted based on volume",
@@ -240,8 +258,9 @@ var tests = []testUpdateSecretTask{
 				Name:      name,
 				Namespace: namespace,
 				Annotations: map[string]string{
-					VersionAnnotation:  "old version",
-					ItemPathAnnotation: itemPath,
+					VersionAnnotation:   "old version",
+					ItemPathAnnotation:  itemPath,
+					OwnerItemAnnotation: ownerItemName,
 				},
 			},
 			Data: expectedSecretData,
@@ -263,6 +282,7 @@ var tests = []testUpdateSecretTask{
 			passKey: password,
 		},
 		expectedRestart: true,
+		expectedEvents:  []string{EventReasonItemFetched, EventReasonSecretUpdated, EventReasonDeploymentRestarted},
 	},
 	{
 		testName: "No secrets need update. No deployment is restarted",
@@ -281,6 +301,19 @@ var tests = []testUpdateSecretTask{
 			},
 		},
 		existingSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					VersionAnnotation:   fmt.Sprint(itemVersion),
+					ItemPathAnnotation:  itemPath,
+					OwnerItemAnnotation: ownerItemName,
+				},
+			},
+			Data: expectedSecretData,
+		},
+		expectedError: nil,
+		expectedResultSecret: &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      name,
 				Namespace: namespace,
@@ -291,6 +324,65 @@ var tests = []testUpdateSecretTask{
 			},
 			Data: expectedSecretData,
 		},
+		opItem: map[string]string{
+			userKey: username,
+			passKey: password,
+		},
+		expectedRestart: false,
+		expectedEvents:  []string{EventReasonItemFetched},
+	},
+	{
+		testName: "OP item has new version. Secret is projected as a service binding. Deployment is restarted and gains a container binding",
+		existingDeployment: &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       deploymentKind,
+				APIVersion: deploymentAPIVersion,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					ProjectionAnnotation: ProjectionServiceBinding,
+				},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Env: []corev1.EnvVar{
+									{
+										Name: name,
+										ValueFrom: &corev1.EnvVarSource{
+											SecretKeyRef: &corev1.SecretKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{
+													Name: name,
+												},
+												Key: passKey,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		existingSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					VersionAnnotation:     "old version",
+					ItemPathAnnotation:    itemPath,
+					OwnerItemAnnotation:   ownerItemName,
+					ProjectionAnnotation:  ProjectionServiceBinding,
+					BindingTypeAnnotation: "postgresql",
+				},
+			},
+			Data: expectedSecretData,
+		},
 		expectedError: nil,
 		expectedResultSecret: &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
@@ -301,13 +393,81 @@ var tests = []testUpdateSecretTask{
 					ItemPathAnnotation: itemPath,
 				},
 			},
+			Data: expectedServiceBindingSecretData,
+		},
+		opItem: map[string]string{
+			userKey: username,
+			passKey: password,
+		},
+		expectedRestart:        true,
+		expectedServiceBinding: true,
+		expectedEvents:         []string{EventReasonItemFetched, EventReasonSecretUpdated, EventReasonDeploymentRestarted},
+	},
+	{
+		testName: "OP item has new version. Secret is projected as a service binding. Deployment is restarted based on volume and gains a binding",
+		existingDeployment: &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       deploymentKind,
+				APIVersion: deploymentAPIVersion,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					ProjectionAnnotation: ProjectionServiceBinding,
+				},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{}},
+						Volumes: []corev1.Volume{
+							{
+								Name: name,
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{
+										SecretName: name,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		existingSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					VersionAnnotation:     "old version",
+					ItemPathAnnotation:    itemPath,
+					OwnerItemAnnotation:   ownerItemName,
+					ProjectionAnnotation:  ProjectionServiceBinding,
+					BindingTypeAnnotation: "postgresql",
+				},
+			},
 			Data: expectedSecretData,
 		},
+		expectedError: nil,
+		expectedResultSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					VersionAnnotation:  fmt.Sprint(itemVersion),
+					ItemPathAnnotation: itemPath,
+				},
+			},
+			Data: expectedServiceBindingSecretData,
+		},
 		opItem: map[string]string{
 			userKey: username,
 			passKey: password,
 		},
-		expectedRestart: false,
+		expectedRestart:        true,
+		expectedServiceBinding: true,
+		expectedEvents:         []string{EventReasonItemFetched, EventReasonSecretUpdated, EventReasonDeploymentRestarted},
 	},
 }
 
@@ -318,10 +478,17 @@ func TestReconcileDepoyment(t *testing.T) {
 			// Register operator types with the runtime scheme.
 			s := scheme.Scheme
 			s.AddKnownTypes(appsv1.SchemeGroupVersion, testData.existingDeployment)
+			assert.NoError(t, onepasswordv1.AddToScheme(s))
+
+			ownerItem := &onepasswordv1.OnePasswordItem{
+				ObjectMeta: metav1.ObjectMeta{Name: ownerItemName, Namespace: namespace},
+				Spec:       onepasswordv1.OnePasswordItemSpec{ItemPath: itemPath},
+			}
 
 			// Objects to track in the fake client.
 			objs := []runtime.Object{
 				testData.existingDeployment,
+				ownerItem,
 			}
 
 			if testData.existingSecret != nil {
@@ -331,24 +498,27 @@ func TestReconcileDepoyment(t *testing.T) {
 			// Create a fake client to mock API calls.
 			cl := fake.NewFakeClientWithScheme(s, objs...)
 
-			opConnectClient := &mocks.TestClient{}
-			mocks.GetGetItemFunc = func(uuid string, vaultUUID string) (*onepassword.Item, error) {
+			secretProvider := &mocks.TestClient{}
+			mocks.GetGetItemFunc = func(vaultRef string, itemRef string) (*onepassword.Item, error) {
 
 				item := onepassword.Item{}
 				item.Fields = generateFields(testData.opItem["username"], testData.opItem["password"])
 				item.Version = itemVersion
-				item.Vault.ID = vaultUUID
-				item.ID = uuid
+				item.Vault.ID = vaultRef
+				item.ID = itemRef
 				return &item, nil
 			}
+			recorder := events.NewFakeRecorder(10)
 			h := &SecretUpdateHandler{
-				client:          cl,
-				opConnectClient: opConnectClient,
+				client:         cl,
+				secretProvider: secretProvider,
+				recorder:       recorder,
 			}
 
 			err := h.UpdateKubernetesSecretsTask()
 
 			assert.Equal(t, testData.expectedError, err)
+			assert.Equal(t, testData.expectedEvents, drainEventReasons(recorder))
 
 			var expectedSecretName string
 			if testData.expectedResultSecret == nil {
@@ -381,10 +551,55 @@ func TestReconcileDepoyment(t *testing.T) {
 			} else {
 				assert.False(t, testData.expectedRestart)
 			}
+
+			//check if the deployment gained a service binding projection
+			if testData.expectedServiceBinding {
+				podSpec := deployment.Spec.Template.Spec
+				assert.True(t, hasVolume(podSpec.Volumes, bindingVolumeName(name)))
+				for _, container := range podSpec.Containers {
+					assert.True(t, hasVolumeMount(container.VolumeMounts, bindingVolumeName(name)))
+					assert.True(t, hasEnvVar(container.Env, ServiceBindingRootEnv))
+				}
+			}
+
+			// A Secret carrying OwnerItemAnnotation should leave its
+			// owning OnePasswordItem Ready and Synced once this item was
+			// fetched successfully, whether or not the Secret's data
+			// changed.
+			gotOwnerItem := &onepasswordv1.OnePasswordItem{}
+			assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: ownerItemName, Namespace: namespace}, gotOwnerItem))
+			assert.Equal(t, metav1.ConditionTrue, conditionStatus(gotOwnerItem, ConditionReady))
+			assert.Equal(t, metav1.ConditionTrue, conditionStatus(gotOwnerItem, ConditionSynced))
+			assert.Equal(t, metav1.ConditionFalse, conditionStatus(gotOwnerItem, ConditionStale))
 		})
 	}
 }
 
+// drainEventReasons reads every event recorder buffered and returns just
+// the reason each carries, in the order they were recorded.
+func drainEventReasons(recorder *events.FakeRecorder) []string {
+	close(recorder.Events)
+	var reasons []string
+	for e := range recorder.Events {
+		fields := strings.Fields(e)
+		if len(fields) >= 2 {
+			reasons = append(reasons, fields[1])
+		}
+	}
+	return reasons
+}
+
+// conditionStatus returns the Status of item's condition of conditionType,
+// or "" if item has no such condition.
+func conditionStatus(item *onepasswordv1.OnePasswordItem, conditionType string) metav1.ConditionStatus {
+	for _, c := range item.Status.Conditions {
+		if c.Type == conditionType {
+			return c.Status
+		}
+	}
+	return ""
+}
+
 func TestIsUpdatedSecret(t *testing.T) {
 
 	secretName := "test-secret"
@@ -397,6 +612,186 @@ func TestIsUpdatedSecret(t *testing.T) {
 	assert.True(t, isUpdatedSecret(secretName, updatedSecrets))
 }
 
+// updateInterceptingClient wraps a client.Client and lets a test script
+// the error returned from each Update call, to exercise
+// SecretUpdateHandler's conflict-retry behavior without a real API
+// server.
+type updateInterceptingClient struct {
+	client.Client
+	updateErr func(callCount int) error
+	calls     int
+}
+
+func (c *updateInterceptingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.calls++
+	if err := c.updateErr(c.calls); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func TestUpdateSecretFromItemPathRetriesOnConflict(t *testing.T) {
+	conflictErr := errors2.NewConflict(schema.GroupResource{Resource: "secrets"}, name, fmt.Errorf("conflict"))
+	notFoundErr := errors2.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+
+	tests := []struct {
+		testName      string
+		updateErr     func(callCount int) error
+		expectUpdated bool
+		expectError   bool
+	}{
+		{
+			testName: "conflict on first update is retried and converges",
+			updateErr: func(callCount int) error {
+				if callCount == 1 {
+					return conflictErr
+				}
+				return nil
+			},
+			expectUpdated: true,
+		},
+		{
+			testName:      "secret deleted mid-update is treated as gone, not an error",
+			updateErr:     func(callCount int) error { return notFoundErr },
+			expectUpdated: false,
+		},
+		{
+			testName:      "conflicts beyond the retry budget surface as an error",
+			updateErr:     func(callCount int) error { return conflictErr },
+			expectError:   true,
+			expectUpdated: false,
+		},
+	}
+
+	for _, testData := range tests {
+		t.Run(testData.testName, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						VersionAnnotation:  "old version",
+						ItemPathAnnotation: itemPath,
+					},
+				},
+				Data: expectedSecretData,
+			}
+
+			base := fake.NewFakeClientWithScheme(scheme.Scheme, secret)
+			cl := &updateInterceptingClient{Client: base, updateErr: testData.updateErr}
+
+			mocks.GetGetItemFunc = func(vaultRef string, itemRef string) (*onepassword.Item, error) {
+				item := onepassword.Item{}
+				item.Fields = generateFields(username, password)
+				item.Version = itemVersion
+				item.Vault.ID = vaultRef
+				item.ID = itemRef
+				return &item, nil
+			}
+
+			h := &SecretUpdateHandler{client: cl, secretProvider: &mocks.TestClient{}}
+
+			updated, err := h.updateSecretFromItemPath(secret, itemPath)
+
+			if testData.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expectUpdated, updated)
+
+			if testData.expectUpdated {
+				got := &corev1.Secret{}
+				assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, got))
+				assert.Equal(t, fmt.Sprint(itemVersion), got.Annotations[VersionAnnotation])
+			}
+		})
+	}
+}
+
+func TestUpdateSecretFromItemPathWithFieldMap(t *testing.T) {
+	section := &onepassword.ItemSection{ID: "sec1", Label: "database"}
+
+	tests := []struct {
+		testName     string
+		fieldMap     string
+		expectedData map[string][]byte
+		expectError  bool
+	}{
+		{
+			testName: "list field is expanded into indexed keys",
+			fieldMap: "hosts=indexed",
+			expectedData: map[string][]byte{
+				"username": []byte(username),
+				"replicas": []byte("r1\nr2"),
+				"hosts.0":  []byte("a.example.com"),
+				"hosts.1":  []byte("b.example.com"),
+			},
+		},
+		{
+			testName: "nested section reference is JSON-encoded",
+			fieldMap: "database.replicas=json",
+			expectedData: map[string][]byte{
+				"username": []byte(username),
+				"hosts":    []byte("a.example.com, b.example.com"),
+				"replicas": []byte(`["r1","r2"]`),
+			},
+		},
+		{
+			testName:    "malformed field-map annotation errors instead of updating the Secret",
+			fieldMap:    "hosts",
+			expectError: true,
+		},
+	}
+
+	for _, testData := range tests {
+		t.Run(testData.testName, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						VersionAnnotation:  "old version",
+						ItemPathAnnotation: itemPath,
+						FieldMapAnnotation: testData.fieldMap,
+					},
+				},
+			}
+
+			cl := fake.NewFakeClientWithScheme(scheme.Scheme, secret)
+
+			mocks.GetGetItemFunc = func(vaultRef string, itemRef string) (*onepassword.Item, error) {
+				item := onepassword.Item{
+					Sections: []*onepassword.ItemSection{section},
+					Fields: []*onepassword.ItemField{
+						{Label: "username", Value: username},
+						{Label: "hosts", Value: "a.example.com, b.example.com"},
+						{Label: "replicas", Section: section, Value: "r1\nr2"},
+					},
+				}
+				item.Version = itemVersion
+				item.Vault.ID = vaultRef
+				item.ID = itemRef
+				return &item, nil
+			}
+
+			h := &SecretUpdateHandler{client: cl, secretProvider: &mocks.TestClient{}}
+
+			updated, err := h.updateSecretFromItemPath(secret, itemPath)
+
+			if testData.expectError {
+				assert.Error(t, err)
+				assert.False(t, updated)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, updated)
+			assert.Equal(t, testData.expectedData, secret.Data)
+		})
+	}
+}
+
 func generateFields(username, password string) []*onepassword.ItemField {
 	fields := []*onepassword.ItemField{
 		{
@@ -0,0 +1,19 @@
+package onepassword
+
+import "fmt"
+
+// serviceAccountProvider is the SecretProvider backed by the op CLI
+// authenticated with a 1Password Service Account token rather than an
+// interactive session. It reuses cliProvider's exec plumbing and differs
+// only in the environment it injects into every invocation.
+type serviceAccountProvider struct {
+	*cliProvider
+}
+
+func newServiceAccountProvider(token string) *serviceAccountProvider {
+	return &serviceAccountProvider{
+		cliProvider: &cliProvider{
+			extraEnv: []string{fmt.Sprintf("OP_SERVICE_ACCOUNT_TOKEN=%s", token)},
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package onepassword
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ProjectionAnnotation opts a Secret or Deployment in to an
+	// alternative representation of a Secret: on a Secret it selects the
+	// data layout written on update, on a Deployment it injects the
+	// projection when the Deployment is restarted. It is not read from
+	// OnePasswordItem resources. The only value currently understood is
+	// ProjectionServiceBinding.
+	ProjectionAnnotation = "operator.1password.io/projection"
+
+	// ProjectionServiceBinding requests that an item be materialized as
+	// a Kubernetes Service Binding Spec Secret: a "type" key plus one
+	// file per credential field, projected into consuming containers
+	// under SERVICE_BINDING_ROOT.
+	ProjectionServiceBinding = "servicebinding"
+
+	// BindingTypeAnnotation sets the binding "type" value (e.g.
+	// "postgresql") written into a service-binding-projected Secret.
+	BindingTypeAnnotation = "operator.1password.io/binding-type"
+
+	// OwnerItemAnnotation names the OnePasswordItem a Secret was created
+	// from, so its status.binding can be kept in sync with the Secret.
+	OwnerItemAnnotation = "operator.1password.io/owner"
+
+	// serviceBindingTypeKey is the Secret data key the Service Binding
+	// Spec reserves for a binding's type.
+	serviceBindingTypeKey = "type"
+
+	// ServiceBindingRootEnv is the environment variable the Service
+	// Binding Spec requires every consuming container to have, pointing
+	// at the directory each binding is mounted under.
+	ServiceBindingRootEnv = "SERVICE_BINDING_ROOT"
+
+	serviceBindingRoot = "/bindings"
+)
+
+func isServiceBindingProjection(annotations map[string]string) bool {
+	return annotations[ProjectionAnnotation] == ProjectionServiceBinding
+}
+
+// kubernetesSecretDataForServiceBinding maps a 1Password item the same
+// way kubernetesSecretDataFromItem does, plus the binding "type" key the
+// Service Binding Spec requires.
+func kubernetesSecretDataForServiceBinding(item *Item, bindingType string) map[string][]byte {
+	data := kubernetesSecretDataFromItem(item)
+	data[serviceBindingTypeKey] = []byte(bindingType)
+	return data
+}
+
+// injectServiceBindingProjection mounts secretName as a Service Binding
+// Spec projection into every container of deployment: a volume at
+// <SERVICE_BINDING_ROOT>/<secretName>, plus the SERVICE_BINDING_ROOT env
+// var. Both are no-ops if already present, so repeated restarts don't
+// pile up duplicate volumes or env entries.
+func injectServiceBindingProjection(deployment *appsv1.Deployment, secretName string) {
+	podSpec := &deployment.Spec.Template.Spec
+	volumeName := bindingVolumeName(secretName)
+
+	if !hasVolume(podSpec.Volumes, volumeName) {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+			},
+		})
+	}
+
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+
+		if !hasVolumeMount(container.VolumeMounts, volumeName) {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: bindingMountPath(secretName),
+				ReadOnly:  true,
+			})
+		}
+
+		if !hasEnvVar(container.Env, ServiceBindingRootEnv) {
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name:  ServiceBindingRootEnv,
+				Value: serviceBindingRoot,
+			})
+		}
+	}
+}
+
+func bindingVolumeName(secretName string) string {
+	return "servicebinding-" + secretName
+}
+
+func bindingMountPath(secretName string) string {
+	return serviceBindingRoot + "/" + secretName
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, v := range volumes {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolumeMount(mounts []corev1.VolumeMount, name string) bool {
+	for _, m := range mounts {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnvVar(envs []corev1.EnvVar, name string) bool {
+	for _, e := range envs {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}